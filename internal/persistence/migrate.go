@@ -0,0 +1,201 @@
+// Package persistence owns the SQLite schema for tasks.db: the versioned
+// migrations that build it up and the bookkeeping table that tracks which
+// ones have run.
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Migration is one forward step in the schema's history. Up runs inside a
+// transaction; if it returns an error the transaction is rolled back and the
+// version is not recorded as applied.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(*sql.Tx) error
+}
+
+// Migrations is the ordered history of the schema. Append, never edit or
+// reorder existing entries.
+var Migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create tasks table",
+		Up: func(tx *sql.Tx) error {
+			_, err := tx.Exec(`
+                CREATE TABLE IF NOT EXISTS tasks (
+                    id INTEGER PRIMARY KEY AUTOINCREMENT,
+                    title TEXT NOT NULL,
+                    priority INTEGER DEFAULT 1,
+                    due_date DATETIME,
+                    recurrence TEXT,
+                    completed BOOLEAN DEFAULT FALSE,
+                    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+                    completed_at DATETIME
+                )
+            `)
+			return err
+		},
+	},
+	{
+		Version: 2,
+		Name:    "index priority and completed_at",
+		Up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority)`); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_completed_at ON tasks (completed_at)`)
+			return err
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add tags",
+		Up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`
+                CREATE TABLE IF NOT EXISTS tags (
+                    id INTEGER PRIMARY KEY AUTOINCREMENT,
+                    name TEXT NOT NULL UNIQUE
+                )
+            `); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`
+                CREATE TABLE IF NOT EXISTS task_tags (
+                    task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+                    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+                    PRIMARY KEY (task_id, tag_id)
+                )
+            `)
+			return err
+		},
+	},
+	{
+		Version: 4,
+		Name:    "add status",
+		Up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`ALTER TABLE tasks ADD COLUMN status TEXT NOT NULL DEFAULT 'todo'`); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`UPDATE tasks SET status = 'done' WHERE completed`)
+			return err
+		},
+	},
+	{
+		Version: 5,
+		Name:    "add notes",
+		Up: func(tx *sql.Tx) error {
+			_, err := tx.Exec(`ALTER TABLE tasks ADD COLUMN notes TEXT`)
+			return err
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add position",
+		Up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`ALTER TABLE tasks ADD COLUMN position INTEGER NOT NULL DEFAULT 0`); err != nil {
+				return err
+			}
+			// Backfill so existing rows keep their creation order once the
+			// TUI starts reordering by this column instead of leaving every
+			// row at the default 0.
+			_, err := tx.Exec(`UPDATE tasks SET position = id WHERE position = 0`)
+			return err
+		},
+	},
+}
+
+// Migrate brings db up to the latest schema version, running each pending
+// migration in its own transaction and recording it in schema_versions.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`
+        CREATE TABLE IF NOT EXISTS schema_versions (
+            version INTEGER PRIMARY KEY,
+            name TEXT NOT NULL,
+            applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+        )
+    `); err != nil {
+		return fmt.Errorf("create schema_versions: %w", err)
+	}
+
+	applied, err := AppliedVersions(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range Migrations {
+		if applied[m.Version] {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("begin migration %d: %w", m.Version, err)
+		}
+
+		if err := m.Up(tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+
+		if _, err := tx.Exec(`INSERT INTO schema_versions (version, name) VALUES (?, ?)`, m.Version, m.Name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("record migration %d: %w", m.Version, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit migration %d: %w", m.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// AppliedVersions returns the set of migration versions already recorded as
+// applied.
+func AppliedVersions(db *sql.DB) (map[int]bool, error) {
+	rows, err := db.Query(`SELECT version FROM schema_versions`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_versions: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+// Status describes one migration's applied/pending state, used by
+// `tasks migrate status`.
+type Status struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// StatusReport returns the applied/pending state of every known migration,
+// in version order.
+func StatusReport(db *sql.DB) ([]Status, error) {
+	applied, err := AppliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]Status, 0, len(Migrations))
+	for _, m := range Migrations {
+		report = append(report, Status{
+			Version: m.Version,
+			Name:    m.Name,
+			Applied: applied[m.Version],
+		})
+	}
+	return report, nil
+}
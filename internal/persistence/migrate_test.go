@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrate_AppliesAllVersionsInOrder(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	report, err := StatusReport(db)
+	if err != nil {
+		t.Fatalf("StatusReport: %v", err)
+	}
+	if len(report) != len(Migrations) {
+		t.Fatalf("got %d statuses, want %d", len(report), len(Migrations))
+	}
+	for i, s := range report {
+		if !s.Applied {
+			t.Errorf("migration %d (%s) not applied", s.Version, s.Name)
+		}
+		if s.Version != Migrations[i].Version {
+			t.Errorf("status %d out of order: got version %d, want %d", i, s.Version, Migrations[i].Version)
+		}
+		if i > 0 && report[i-1].Version >= s.Version {
+			t.Errorf("migrations out of order: %d did not increase after %d", s.Version, report[i-1].Version)
+		}
+	}
+}
+
+func TestMigrate_IsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("first Migrate: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+
+	applied, err := AppliedVersions(db)
+	if err != nil {
+		t.Fatalf("AppliedVersions: %v", err)
+	}
+	if len(applied) != len(Migrations) {
+		t.Fatalf("got %d applied versions, want %d", len(applied), len(Migrations))
+	}
+}
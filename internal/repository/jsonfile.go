@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// jsonStore is the on-disk shape of a JSONFileRepository's single file.
+type jsonStore struct {
+	NextID int64  `json:"next_id"`
+	Tasks  []Task `json:"tasks"`
+}
+
+// JSONFileRepository stores tasks as a single JSON file, written atomically
+// (temp file + rename) on every mutation. It's a lighter-weight alternative
+// to SQLiteRepository for users who don't want a database file.
+type JSONFileRepository struct {
+	path string
+}
+
+// OpenJSONFile opens (creating if needed) the JSON store at path.
+func OpenJSONFile(path string) (*JSONFileRepository, error) {
+	r := &JSONFileRepository{path: path}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := r.save(jsonStore{NextID: 1}); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *JSONFileRepository) load() (jsonStore, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return jsonStore{}, err
+	}
+	var store jsonStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		return jsonStore{}, err
+	}
+	return store, nil
+}
+
+func (r *JSONFileRepository) save(store jsonStore) error {
+	return AtomicWriteJSON(r.path, store)
+}
+
+func (r *JSONFileRepository) find(store *jsonStore, id string) (*Task, error) {
+	for i := range store.Tasks {
+		if strconv.FormatInt(store.Tasks[i].ID, 10) == id {
+			return &store.Tasks[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// mutate loads the store, locates the task by id, applies fn, and saves.
+func (r *JSONFileRepository) mutate(id string, fn func(*Task) error) error {
+	store, err := r.load()
+	if err != nil {
+		return err
+	}
+	t, err := r.find(&store, id)
+	if err != nil {
+		return err
+	}
+	if err := fn(t); err != nil {
+		return err
+	}
+	return r.save(store)
+}
+
+func (r *JSONFileRepository) Add(title string, priority int, due *time.Time, recurrence *string) (Task, error) {
+	store, err := r.load()
+	if err != nil {
+		return Task{}, err
+	}
+
+	t := Task{
+		ID:         store.NextID,
+		Title:      title,
+		Priority:   priority,
+		DueDate:    due,
+		Recurrence: recurrence,
+		Status:     StatusTodo,
+		Position:   store.NextID,
+		CreatedAt:  time.Now(),
+	}
+	store.NextID++
+	store.Tasks = append(store.Tasks, t)
+
+	if err := r.save(store); err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+func (r *JSONFileRepository) List() ([]Task, error) {
+	store, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]Task, len(store.Tasks))
+	copy(tasks, store.Tasks)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority > tasks[j].Priority
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+	return tasks, nil
+}
+
+func (r *JSONFileRepository) Complete(id string) error {
+	store, err := r.load()
+	if err != nil {
+		return err
+	}
+	t, err := r.find(&store, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t.Status = StatusDone
+	t.CompletedAt = &now
+
+	if t.Recurrence != nil {
+		interval, err := ParseInterval(*t.Recurrence)
+		if err != nil {
+			return err
+		}
+		base := now
+		if t.DueDate != nil {
+			base = *t.DueDate
+		}
+		next := base.Add(interval)
+		store.Tasks = append(store.Tasks, Task{
+			ID:         store.NextID,
+			Title:      t.Title,
+			Priority:   t.Priority,
+			DueDate:    &next,
+			Recurrence: t.Recurrence,
+			Status:     StatusTodo,
+			Position:   store.NextID,
+			CreatedAt:  now,
+		})
+		store.NextID++
+	}
+
+	return r.save(store)
+}
+
+func (r *JSONFileRepository) SetStatus(id string, status Status) error {
+	return r.mutate(id, func(t *Task) error {
+		t.Status = status
+		if status == StatusDone {
+			now := time.Now()
+			t.CompletedAt = &now
+		} else {
+			t.CompletedAt = nil
+		}
+		return nil
+	})
+}
+
+func (r *JSONFileRepository) SetDueDate(id string, due time.Time) error {
+	return r.mutate(id, func(t *Task) error {
+		t.DueDate = &due
+		return nil
+	})
+}
+
+func (r *JSONFileRepository) SetTitle(id string, title string) error {
+	return r.mutate(id, func(t *Task) error {
+		t.Title = title
+		return nil
+	})
+}
+
+func (r *JSONFileRepository) SetPriority(id string, priority int) error {
+	return r.mutate(id, func(t *Task) error {
+		t.Priority = priority
+		return nil
+	})
+}
+
+func (r *JSONFileRepository) SetNotes(id string, notes string) error {
+	return r.mutate(id, func(t *Task) error {
+		t.Notes = &notes
+		return nil
+	})
+}
+
+func (r *JSONFileRepository) SetTags(id string, tags []string) error {
+	return r.mutate(id, func(t *Task) error {
+		t.Tags = tags
+		return nil
+	})
+}
+
+// Reorder swaps id's Position with its neighbor in position order, moving it
+// one slot earlier (direction < 0) or later (direction > 0).
+func (r *JSONFileRepository) Reorder(id string, direction int) error {
+	store, err := r.load()
+	if err != nil {
+		return err
+	}
+	t, err := r.find(&store, id)
+	if err != nil {
+		return err
+	}
+
+	ordered := make([]*Task, len(store.Tasks))
+	for i := range store.Tasks {
+		ordered[i] = &store.Tasks[i]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+
+	idx := -1
+	for i, ot := range ordered {
+		if ot == t {
+			idx = i
+			break
+		}
+	}
+
+	neighbor := idx + direction
+	if neighbor < 0 || neighbor >= len(ordered) {
+		return nil
+	}
+
+	ordered[idx].Position, ordered[neighbor].Position = ordered[neighbor].Position, ordered[idx].Position
+	return r.save(store)
+}
+
+func (r *JSONFileRepository) Delete(id string) error {
+	store, err := r.load()
+	if err != nil {
+		return err
+	}
+	for i := range store.Tasks {
+		if strconv.FormatInt(store.Tasks[i].ID, 10) == id {
+			store.Tasks = append(store.Tasks[:i], store.Tasks[i+1:]...)
+			return r.save(store)
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *JSONFileRepository) DueSoon(window time.Duration) ([]Task, error) {
+	store, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(window)
+	var due []Task
+	for _, t := range store.Tasks {
+		if t.Status == StatusDone || t.DueDate == nil {
+			continue
+		}
+		if t.DueDate.After(deadline) {
+			continue
+		}
+		due = append(due, t)
+	}
+	return due, nil
+}
+
+func (r *JSONFileRepository) Import(tasks []Task) error {
+	store, err := r.load()
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		t.ID = store.NextID
+		t.Position = store.NextID
+		store.NextID++
+		if t.Status == "" {
+			t.Status = StatusTodo
+		}
+		store.Tasks = append(store.Tasks, t)
+	}
+	return r.save(store)
+}
+
+func (r *JSONFileRepository) Close() error {
+	return nil
+}
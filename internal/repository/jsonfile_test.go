@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func openTestJSONFile(t *testing.T) *JSONFileRepository {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo, err := OpenJSONFile(path)
+	if err != nil {
+		t.Fatalf("OpenJSONFile: %v", err)
+	}
+	return repo
+}
+
+func TestJSONFileRepository_AddListComplete(t *testing.T) {
+	repo := openTestJSONFile(t)
+
+	task, err := repo.Add("write tests", 3, nil, nil)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "write tests" {
+		t.Fatalf("List = %+v, want one task titled %q", tasks, "write tests")
+	}
+
+	id := strconv.FormatInt(task.ID, 10)
+	if err := repo.Complete(id); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	tasks, err = repo.List()
+	if err != nil {
+		t.Fatalf("List after Complete: %v", err)
+	}
+	if !tasks[0].Done() {
+		t.Errorf("task not marked done after Complete")
+	}
+}
+
+func TestJSONFileRepository_CompleteRecurringAddsNextOccurrence(t *testing.T) {
+	repo := openTestJSONFile(t)
+
+	recurrence := "1d"
+	task, err := repo.Add("water plants", 1, nil, &recurrence)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if err := repo.Complete(strconv.FormatInt(task.ID, 10)); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks after recurring Complete, want 2", len(tasks))
+	}
+
+	var pending *Task
+	for i := range tasks {
+		if !tasks[i].Done() {
+			pending = &tasks[i]
+		}
+	}
+	if pending == nil {
+		t.Fatal("no pending occurrence was created")
+	}
+	if pending.DueDate == nil {
+		t.Fatal("recurring occurrence has no due date")
+	}
+}
+
+func TestJSONFileRepository_MutateMissingIDReturnsErrNotFound(t *testing.T) {
+	repo := openTestJSONFile(t)
+
+	const missing = "999"
+	cases := map[string]func() error{
+		"SetStatus":   func() error { return repo.SetStatus(missing, StatusDoing) },
+		"SetDueDate":  func() error { return repo.SetDueDate(missing, time.Now()) },
+		"SetTitle":    func() error { return repo.SetTitle(missing, "x") },
+		"SetPriority": func() error { return repo.SetPriority(missing, 2) },
+		"SetNotes":    func() error { return repo.SetNotes(missing, "note") },
+		"Delete":      func() error { return repo.Delete(missing) },
+	}
+
+	for name, call := range cases {
+		if err := call(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s on missing id: got %v, want ErrNotFound", name, err)
+		}
+	}
+}
+
+func TestJSONFileRepository_ExportImportRoundTrip(t *testing.T) {
+	repo := openTestJSONFile(t)
+
+	if _, err := repo.Add("task one", 2, nil, nil); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	if err := Export(repo, exportPath); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	dest := openTestJSONFile(t)
+	n, err := ImportFile(dest, exportPath)
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("ImportFile imported %d tasks, want 1", n)
+	}
+
+	tasks, err := dest.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "task one" {
+		t.Fatalf("List = %+v, want one task titled %q", tasks, "task one")
+	}
+}
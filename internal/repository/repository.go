@@ -0,0 +1,178 @@
+// Package repository decouples the CLI from any one storage engine. Task
+// and Status are the shared domain types; TaskRepository is implemented by
+// SQLiteRepository and JSONFileRepository.
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by repository methods when the given task ID
+// doesn't exist.
+var ErrNotFound = errors.New("task not found")
+
+// Status is a task's position in the todo -> doing -> done workflow, or
+// blocked if it's stuck outside that flow.
+type Status string
+
+const (
+	StatusTodo    Status = "todo"
+	StatusDoing   Status = "doing"
+	StatusDone    Status = "done"
+	StatusBlocked Status = "blocked"
+)
+
+// Statuses is the fixed column order used by `board` and the stats charts.
+var Statuses = []Status{StatusTodo, StatusDoing, StatusBlocked, StatusDone}
+
+// ParseStatus validates a status string as used by `tasks mv`.
+func ParseStatus(raw string) (Status, error) {
+	for _, s := range Statuses {
+		if raw == string(s) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status %q: must be one of todo, doing, done, blocked", raw)
+}
+
+// Task is the domain type shared by every storage backend.
+type Task struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Priority    int        `json:"priority"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Recurrence  *string    `json:"recurrence,omitempty"`
+	Status      Status     `json:"status"`
+	Notes       *string    `json:"notes,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Position    int64      `json:"position"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Done reports whether the task is complete, preserving the semantics of
+// the old boolean Completed field.
+func (t Task) Done() bool {
+	return t.Status == StatusDone
+}
+
+// TaskRepository is the storage-agnostic interface every CLI command and
+// the TUI operate against.
+type TaskRepository interface {
+	Add(title string, priority int, due *time.Time, recurrence *string) (Task, error)
+	List() ([]Task, error)
+	Complete(id string) error
+	SetStatus(id string, status Status) error
+	SetDueDate(id string, due time.Time) error
+	SetTitle(id string, title string) error
+	SetPriority(id string, priority int) error
+	SetNotes(id string, notes string) error
+	SetTags(id string, tags []string) error
+	// Reorder moves id one slot earlier (direction < 0) or later (direction > 0)
+	// in the manually-curated position used by the TUI's reorder keybindings.
+	// It does not affect the priority-based ordering List returns.
+	Reorder(id string, direction int) error
+	Delete(id string) error
+	DueSoon(window time.Duration) ([]Task, error)
+	// Import inserts tasks as-is (preserving title/priority/due/recurrence/
+	// status/notes/timestamps but not necessarily ID), used by `tasks import`.
+	Import(tasks []Task) error
+	Close() error
+}
+
+// Open resolves a repository from a --store flag value ("sqlite" or "json",
+// default "sqlite") backed by path.
+func Open(store, path string) (TaskRepository, error) {
+	switch store {
+	case "", "sqlite":
+		return OpenSQLite(path)
+	case "json":
+		return OpenJSONFile(path)
+	default:
+		return nil, fmt.Errorf("unknown store %q: must be sqlite or json", store)
+	}
+}
+
+// ParseInterval parses a recurrence/relative-due spec such as "24h", "3d",
+// or "1w". Go's time.ParseDuration doesn't support day/week units natively.
+func ParseInterval(raw string) (time.Duration, error) {
+	switch {
+	case strings.HasSuffix(raw, "d"):
+		n, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	case strings.HasSuffix(raw, "w"):
+		n, err := strconv.Atoi(strings.TrimSuffix(raw, "w"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	default:
+		return time.ParseDuration(raw)
+	}
+}
+
+// AtomicWriteJSON marshals v as indented JSON and writes it to path via a
+// temp file + rename, so a crash mid-write can't corrupt the existing file.
+func AtomicWriteJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".tasks-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Export writes every task in repo to path as a JSON array, in the
+// priority order List returns.
+func Export(repo TaskRepository, path string) error {
+	tasks, err := repo.List()
+	if err != nil {
+		return err
+	}
+	return AtomicWriteJSON(path, tasks)
+}
+
+// ImportFile reads a JSON task array produced by Export and inserts it into
+// repo.
+func ImportFile(repo TaskRepository, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var tasks []Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return 0, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if err := repo.Import(tasks); err != nil {
+		return 0, err
+	}
+	return len(tasks), nil
+}
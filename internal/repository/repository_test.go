@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	cases := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{raw: "24h", want: 24 * time.Hour},
+		{raw: "3d", want: 3 * 24 * time.Hour},
+		{raw: "1w", want: 7 * 24 * time.Hour},
+		{raw: "2w", want: 2 * 7 * 24 * time.Hour},
+		{raw: "90m", want: 90 * time.Minute},
+		{raw: "nope", wantErr: true},
+		{raw: "xd", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseInterval(c.raw)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("ParseInterval(%q) = %v, want error", c.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseInterval(%q) unexpected error: %v", c.raw, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseInterval(%q) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, s := range Statuses {
+		got, err := ParseStatus(string(s))
+		if err != nil {
+			t.Errorf("ParseStatus(%q) unexpected error: %v", s, err)
+		}
+		if got != s {
+			t.Errorf("ParseStatus(%q) = %q, want %q", s, got, s)
+		}
+	}
+
+	if _, err := ParseStatus("archived"); err == nil {
+		t.Error("ParseStatus(\"archived\") = nil error, want error")
+	}
+}
@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/Phillip-D-Shields/tracker-thingie/internal/persistence"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteRepository stores tasks in a SQLite database, migrated on open via
+// internal/persistence.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens (creating if needed) the SQLite database at path and
+// brings its schema up to date.
+func OpenSQLite(path string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := persistence.Migrate(db); err != nil {
+		return nil, err
+	}
+	return &SQLiteRepository{db: db}, nil
+}
+
+// DB exposes the underlying database connection for commands that are
+// inherently SQLite-specific (stats charts, `migrate status`).
+func (r *SQLiteRepository) DB() *sql.DB {
+	return r.db
+}
+
+func (r *SQLiteRepository) Add(title string, priority int, due *time.Time, recurrence *string) (Task, error) {
+	res, err := r.db.Exec(`
+        INSERT INTO tasks (title, priority, due_date, recurrence)
+        VALUES (?, ?, ?, ?)
+    `, title, priority, due, recurrence)
+	if err != nil {
+		return Task{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Task{}, err
+	}
+	// Seed position from the new row's id so it sorts after every existing
+	// task until a TUI reorder moves it.
+	if _, err := r.db.Exec(`UPDATE tasks SET position = ? WHERE id = ?`, id, id); err != nil {
+		return Task{}, err
+	}
+	return Task{ID: id, Title: title, Priority: priority, DueDate: due, Recurrence: recurrence, Status: StatusTodo, Position: id}, nil
+}
+
+func (r *SQLiteRepository) List() ([]Task, error) {
+	rows, err := r.db.Query(`
+        SELECT id, title, priority, status, due_date, recurrence, notes, position, created_at, completed_at
+        FROM tasks
+        ORDER BY priority DESC, created_at DESC
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []Task
+	for rows.Next() {
+		var t Task
+		var status string
+		if err := rows.Scan(&t.ID, &t.Title, &t.Priority, &status, &t.DueDate, &t.Recurrence, &t.Notes, &t.Position, &t.CreatedAt, &t.CompletedAt); err != nil {
+			return nil, err
+		}
+		t.Status = Status(status)
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagsByTask, err := r.loadTags()
+	if err != nil {
+		return nil, err
+	}
+	for i := range tasks {
+		tasks[i].Tags = tagsByTask[tasks[i].ID]
+	}
+	return tasks, nil
+}
+
+// loadTags returns every task's tags in one round trip, keyed by task ID, so
+// List doesn't need an N+1 query per task. DueSoon does not load tags.
+func (r *SQLiteRepository) loadTags() (map[int64][]string, error) {
+	rows, err := r.db.Query(`
+        SELECT task_tags.task_id, tags.name
+        FROM task_tags
+        JOIN tags ON tags.id = task_tags.tag_id
+        ORDER BY tags.name
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byTask := make(map[int64][]string)
+	for rows.Next() {
+		var taskID int64
+		var name string
+		if err := rows.Scan(&taskID, &name); err != nil {
+			return nil, err
+		}
+		byTask[taskID] = append(byTask[taskID], name)
+	}
+	return byTask, rows.Err()
+}
+
+func (r *SQLiteRepository) Complete(id string) error {
+	var title string
+	var priority int
+	var dueDate *time.Time
+	var recurrence *string
+	err := r.db.QueryRow(`
+        SELECT title, priority, due_date, recurrence
+        FROM tasks
+        WHERE id = ?
+    `, id).Scan(&title, &priority, &dueDate, &recurrence)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := r.SetStatus(id, StatusDone); err != nil {
+		return err
+	}
+
+	if recurrence == nil {
+		return nil
+	}
+
+	interval, err := ParseInterval(*recurrence)
+	if err != nil {
+		return err
+	}
+
+	base := time.Now()
+	if dueDate != nil {
+		base = *dueDate
+	}
+	next := base.Add(interval)
+	_, err = r.Add(title, priority, &next, recurrence)
+	return err
+}
+
+// SetStatus moves a task to a new status. Moving to done also sets the
+// legacy completed/completed_at columns for backward compatibility; moving
+// away from done clears them.
+func (r *SQLiteRepository) SetStatus(id string, status Status) error {
+	if status == StatusDone {
+		res, err := r.db.Exec(`
+            UPDATE tasks
+            SET status = ?, completed = TRUE, completed_at = CURRENT_TIMESTAMP
+            WHERE id = ?
+        `, string(status), id)
+		return requireRowsAffected(res, err)
+	}
+
+	res, err := r.db.Exec(`
+        UPDATE tasks
+        SET status = ?, completed = FALSE, completed_at = NULL
+        WHERE id = ?
+    `, string(status), id)
+	return requireRowsAffected(res, err)
+}
+
+func (r *SQLiteRepository) SetDueDate(id string, due time.Time) error {
+	res, err := r.db.Exec(`UPDATE tasks SET due_date = ? WHERE id = ?`, due, id)
+	return requireRowsAffected(res, err)
+}
+
+func (r *SQLiteRepository) SetTitle(id string, title string) error {
+	res, err := r.db.Exec(`UPDATE tasks SET title = ? WHERE id = ?`, title, id)
+	return requireRowsAffected(res, err)
+}
+
+func (r *SQLiteRepository) SetPriority(id string, priority int) error {
+	res, err := r.db.Exec(`UPDATE tasks SET priority = ? WHERE id = ?`, priority, id)
+	return requireRowsAffected(res, err)
+}
+
+func (r *SQLiteRepository) SetNotes(id string, notes string) error {
+	res, err := r.db.Exec(`UPDATE tasks SET notes = ? WHERE id = ?`, notes, id)
+	return requireRowsAffected(res, err)
+}
+
+func (r *SQLiteRepository) Delete(id string) error {
+	res, err := r.db.Exec(`DELETE FROM tasks WHERE id = ?`, id)
+	return requireRowsAffected(res, err)
+}
+
+// SetTags replaces id's tag set, creating any tag rows that don't already
+// exist.
+func (r *SQLiteRepository) SetTags(id string, tags []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	if err := tx.QueryRow(`SELECT 1 FROM tasks WHERE id = ?`, id).Scan(&exists); err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM task_tags WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+
+	for _, name := range tags {
+		if name == "" {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO tags (name) VALUES (?)`, name); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`
+            INSERT INTO task_tags (task_id, tag_id)
+            SELECT ?, id FROM tags WHERE name = ?
+        `, id, name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Reorder moves id one slot earlier (direction < 0) or later (direction > 0)
+// among tasks ordered by position, swapping position with that neighbor.
+func (r *SQLiteRepository) Reorder(id string, direction int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var position int64
+	if err := tx.QueryRow(`SELECT position FROM tasks WHERE id = ?`, id).Scan(&position); err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	var neighborID, neighborPosition int64
+	var neighborQuery string
+	if direction < 0 {
+		neighborQuery = `SELECT id, position FROM tasks WHERE position < ? ORDER BY position DESC LIMIT 1`
+	} else {
+		neighborQuery = `SELECT id, position FROM tasks WHERE position > ? ORDER BY position ASC LIMIT 1`
+	}
+	if err := tx.QueryRow(neighborQuery, position).Scan(&neighborID, &neighborPosition); err == sql.ErrNoRows {
+		return tx.Commit()
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE tasks SET position = ? WHERE id = ?`, neighborPosition, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE tasks SET position = ? WHERE id = ?`, position, neighborID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// requireRowsAffected turns a successful-but-no-op Exec (no row matched id)
+// into ErrNotFound, matching JSONFileRepository's find/mutate behavior.
+func requireRowsAffected(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) DueSoon(window time.Duration) ([]Task, error) {
+	rows, err := r.db.Query(`
+        SELECT id, title, priority, status, due_date, recurrence, notes, position, created_at, completed_at
+        FROM tasks
+        WHERE status != 'done' AND due_date IS NOT NULL
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deadline := time.Now().Add(window)
+	var due []Task
+	for rows.Next() {
+		var t Task
+		var status string
+		if err := rows.Scan(&t.ID, &t.Title, &t.Priority, &status, &t.DueDate, &t.Recurrence, &t.Notes, &t.Position, &t.CreatedAt, &t.CompletedAt); err != nil {
+			return nil, err
+		}
+		t.Status = Status(status)
+		if t.DueDate != nil && !t.DueDate.After(deadline) {
+			due = append(due, t)
+		}
+	}
+	return due, rows.Err()
+}
+
+func (r *SQLiteRepository) Import(tasks []Task) error {
+	for _, t := range tasks {
+		status := t.Status
+		if status == "" {
+			status = StatusTodo
+		}
+		createdAt := t.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+		// Store timestamps in the same UTC "YYYY-MM-DD HH:MM:SS" text that
+		// CURRENT_TIMESTAMP writes, so imported rows compare like native ones.
+		var completedAt interface{}
+		if t.CompletedAt != nil {
+			completedAt = t.CompletedAt.UTC().Format("2006-01-02 15:04:05")
+		}
+		res, err := r.db.Exec(`
+            INSERT INTO tasks (title, priority, due_date, recurrence, status, notes, completed, completed_at, created_at)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+        `, t.Title, t.Priority, t.DueDate, t.Recurrence, string(status), t.Notes, status == StatusDone, completedAt, createdAt.UTC().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if _, err := r.db.Exec(`UPDATE tasks SET position = ? WHERE id = ?`, id, id); err != nil {
+			return err
+		}
+		if len(t.Tags) > 0 {
+			if err := r.SetTags(strconv.FormatInt(id, 10), t.Tags); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func openTestSQLite(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	repo, err := OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestSQLiteRepository_ImportPreservesCreatedAt(t *testing.T) {
+	repo := openTestSQLite(t)
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := repo.Import([]Task{{Title: "old task", Priority: 1, Status: StatusTodo, CreatedAt: created}}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(tasks))
+	}
+	if !tasks[0].CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", tasks[0].CreatedAt, created)
+	}
+}
+
+func TestSQLiteRepository_ImportPreservesTags(t *testing.T) {
+	repo := openTestSQLite(t)
+
+	if err := repo.Import([]Task{{Title: "tagged task", Priority: 1, Status: StatusTodo, Tags: []string{"work", "urgent"}}}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(tasks))
+	}
+	if got := tasks[0].Tags; len(got) != 2 || got[0] != "urgent" || got[1] != "work" {
+		t.Errorf("Tags = %v, want [urgent work]", got)
+	}
+}
+
+func TestSQLiteRepository_MutateMissingIDReturnsErrNotFound(t *testing.T) {
+	repo := openTestSQLite(t)
+
+	const missing = "999"
+	cases := map[string]func() error{
+		"SetStatus":   func() error { return repo.SetStatus(missing, StatusDoing) },
+		"SetDueDate":  func() error { return repo.SetDueDate(missing, time.Now()) },
+		"SetTitle":    func() error { return repo.SetTitle(missing, "x") },
+		"SetPriority": func() error { return repo.SetPriority(missing, 2) },
+		"SetNotes":    func() error { return repo.SetNotes(missing, "note") },
+		"SetTags":     func() error { return repo.SetTags(missing, []string{"x"}) },
+		"Reorder":     func() error { return repo.Reorder(missing, -1) },
+		"Delete":      func() error { return repo.Delete(missing) },
+	}
+
+	for name, call := range cases {
+		if err := call(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s on missing id: got %v, want ErrNotFound", name, err)
+		}
+	}
+}
+
+func TestSQLiteRepository_SetTitleFoundUpdates(t *testing.T) {
+	repo := openTestSQLite(t)
+
+	task, err := repo.Add("original", 1, nil, nil)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	id := strconv.FormatInt(task.ID, 10)
+	if err := repo.SetTitle(id, "renamed"); err != nil {
+		t.Fatalf("SetTitle: %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if tasks[0].Title != "renamed" {
+		t.Errorf("Title = %q, want %q", tasks[0].Title, "renamed")
+	}
+}
+
+func TestSQLiteRepository_SetTagsReplacesExistingTags(t *testing.T) {
+	repo := openTestSQLite(t)
+
+	task, err := repo.Add("tag me", 1, nil, nil)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	id := strconv.FormatInt(task.ID, 10)
+
+	if err := repo.SetTags(id, []string{"work", "urgent"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	if err := repo.SetTags(id, []string{"home"}); err != nil {
+		t.Fatalf("SetTags (replace): %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := tasks[0].Tags; len(got) != 1 || got[0] != "home" {
+		t.Errorf("Tags = %v, want [home]", got)
+	}
+}
+
+func TestSQLiteRepository_ReorderSwapsWithNeighbor(t *testing.T) {
+	repo := openTestSQLite(t)
+
+	first, err := repo.Add("first", 1, nil, nil)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	second, err := repo.Add("second", 1, nil, nil)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if err := repo.Reorder(strconv.FormatInt(second.ID, 10), -1); err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+
+	tasks, err := repo.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	byID := make(map[int64]Task)
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	if byID[second.ID].Position >= byID[first.ID].Position {
+		t.Errorf("expected second task's position (%d) to precede first's (%d) after Reorder", byID[second.ID].Position, byID[first.ID].Position)
+	}
+}
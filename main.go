@@ -1,30 +1,40 @@
 package main
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/Phillip-D-Shields/tracker-thingie/internal/persistence"
+	"github.com/Phillip-D-Shields/tracker-thingie/internal/repository"
 	"github.com/fatih/color"
-	"github.com/go-echarts/go-echarts/v2/charts"
-	"github.com/go-echarts/go-echarts/v2/opts"
-	"github.com/go-echarts/go-echarts/v2/types"
+	"github.com/gen2brain/beeep"
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
-	_ "modernc.org/sqlite"
 )
 
-type Task struct {
-	ID          int64
-	Title       string
-	Priority    int
-	DueDate     *time.Time
-	Completed   bool
-	CreatedAt   time.Time
-	CompletedAt *time.Time
-}
+const dueDateLayout = "2006-01-02 15:04"
+
+// Task and Status live in internal/repository now; aliased here so the rest
+// of this file (and tui.go) didn't need to change on the repository split.
+type (
+	Task   = repository.Task
+	Status = repository.Status
+)
+
+const (
+	StatusTodo    = repository.StatusTodo
+	StatusDoing   = repository.StatusDoing
+	StatusDone    = repository.StatusDone
+	StatusBlocked = repository.StatusBlocked
+)
+
+var (
+	Statuses    = repository.Statuses
+	ParseStatus = repository.ParseStatus
+)
 
 var (
 	green   = color.New(color.FgGreen).SprintFunc()
@@ -35,16 +45,39 @@ var (
 )
 
 func main() {
-	db, err := initDB()
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
+	var store string
+	var repo repository.TaskRepository
 
 	var rootCmd = &cobra.Command{
 		Use:   "tasks",
 		Short: "A simple task tracker",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if store == "" {
+				store = os.Getenv("TASKS_STORE")
+			}
+			if store == "" {
+				store = "sqlite"
+			}
+			path := "tasks.db"
+			if store == "json" {
+				path = "tasks.json"
+			}
+
+			r, err := repository.Open(store, path)
+			if err != nil {
+				return err
+			}
+			repo = r
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if repo == nil {
+				return nil
+			}
+			return repo.Close()
+		},
 	}
+	rootCmd.PersistentFlags().StringVar(&store, "store", "", "Storage backend: sqlite or json (env TASKS_STORE)")
 
 	var addCmd = &cobra.Command{
 		Use:   "add [task title]",
@@ -52,8 +85,28 @@ func main() {
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			priority, _ := cmd.Flags().GetInt("priority")
+			dueRaw, _ := cmd.Flags().GetString("due")
+			recurring, _ := cmd.Flags().GetString("recurring")
 			title := args[0]
-			if err := addTask(db, title, priority); err != nil {
+
+			var due *time.Time
+			if dueRaw != "" {
+				d, err := parseDueDate(dueRaw)
+				if err != nil {
+					log.Fatal(err)
+				}
+				due = &d
+			}
+
+			var recurrence *string
+			if recurring != "" {
+				if _, err := repository.ParseInterval(recurring); err != nil {
+					log.Fatal(fmt.Errorf("invalid recurrence %q: expected a duration like 1d/1w", recurring))
+				}
+				recurrence = &recurring
+			}
+
+			if _, err := repo.Add(title, priority, due, recurrence); err != nil {
 				log.Fatal(err)
 			}
 			fmt.Printf("%s: %s (Priority: %s)\n",
@@ -63,12 +116,41 @@ func main() {
 		},
 	}
 	addCmd.Flags().IntP("priority", "p", 1, "Task priority (1-5)")
+	addCmd.Flags().String("due", "", "Due date, absolute (2006-01-02 15:04) or relative (24h, 3d, 1w)")
+	addCmd.Flags().String("recurring", "", "Recurrence spec applied when the task is completed (e.g. 1d, 1w)")
+
+	var modCmd = &cobra.Command{
+		Use:   "mod [task ID]",
+		Short: "Modify an existing task",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			dueRaw, _ := cmd.Flags().GetString("due")
+			if dueRaw == "" {
+				fmt.Println(yellow("Nothing to modify, provide --due"))
+				return
+			}
+
+			due, err := parseDueDate(dueRaw)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := repo.SetDueDate(id, due); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s task %s due date to %s\n",
+				green("Updated"),
+				blue(id),
+				yellow(due.Format(dueDateLayout)))
+		},
+	}
+	modCmd.Flags().String("due", "", "New due date, absolute (2006-01-02 15:04) or relative (24h, 3d, 1w)")
 
 	var listCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List all tasks",
 		Run: func(cmd *cobra.Command, args []string) {
-			tasks, err := listTasks(db)
+			tasks, err := repo.List()
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -77,16 +159,13 @@ func main() {
 			fmt.Println(strings.Repeat("=", 50))
 
 			for _, task := range tasks {
-				status := red("[ ]")
-				if task.Completed {
-					status = green("[âœ“]")
-				}
 				priorityColor := getPriorityColor(task.Priority)
-				fmt.Printf("%s %d. %s (Priority: %s)\n",
-					status,
+				fmt.Printf("%s %d. %s (Priority: %s)%s\n",
+					statusMarker(task.Status),
 					task.ID,
 					blue(task.Title),
-					priorityColor(fmt.Sprintf("%d", task.Priority)))
+					priorityColor(fmt.Sprintf("%d", task.Priority)),
+					formatDueDate(task))
 			}
 			fmt.Println(strings.Repeat("=", 50))
 		},
@@ -94,11 +173,43 @@ func main() {
 
 	var statsCmd = &cobra.Command{
 		Use:   "stats",
-		Short: "Show task statistics and charts",
+		Short: "Show task statistics and render a dashboard",
 		Run: func(cmd *cobra.Command, args []string) {
-			generateStats(db)
+			sqliteRepo, ok := repo.(*repository.SQLiteRepository)
+			if !ok {
+				log.Fatal("stats requires --store sqlite")
+			}
+
+			sinceRaw, _ := cmd.Flags().GetString("since")
+			untilRaw, _ := cmd.Flags().GetString("until")
+			out, _ := cmd.Flags().GetString("out")
+
+			until := time.Now()
+			if untilRaw != "" {
+				t, err := time.ParseInLocation("2006-01-02", untilRaw, time.Local)
+				if err != nil {
+					log.Fatal(fmt.Errorf("invalid --until %q: expected 2006-01-02", untilRaw))
+				}
+				until = t
+			}
+
+			since := until.AddDate(0, 0, -30)
+			if sinceRaw != "" {
+				t, err := time.ParseInLocation("2006-01-02", sinceRaw, time.Local)
+				if err != nil {
+					log.Fatal(fmt.Errorf("invalid --since %q: expected 2006-01-02", sinceRaw))
+				}
+				since = t
+			}
+
+			if err := generateStats(sqliteRepo.DB(), since, until, out); err != nil {
+				log.Fatal(err)
+			}
 		},
 	}
+	statsCmd.Flags().String("since", "", "Start of the stats window (2006-01-02), default 30 days before --until")
+	statsCmd.Flags().String("until", "", "End of the stats window (2006-01-02), default now")
+	statsCmd.Flags().String("out", "dashboard.html", "Dashboard HTML output path")
 
 	var completeCmd = &cobra.Command{
 		Use:   "complete [task ID]",
@@ -106,7 +217,7 @@ func main() {
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			id := args[0]
-			if err := completeTask(db, id); err != nil {
+			if err := repo.Complete(id); err != nil {
 				log.Fatal(err)
 			}
 			fmt.Printf("%s task %s\n",
@@ -115,7 +226,119 @@ func main() {
 		},
 	}
 
-	rootCmd.AddCommand(addCmd, listCmd, completeCmd, statsCmd)
+	var notifyCmd = &cobra.Command{
+		Use:   "notify",
+		Short: "Send desktop notifications for tasks due soon",
+		Run: func(cmd *cobra.Command, args []string) {
+			window, _ := cmd.Flags().GetDuration("window")
+			count, err := notifyDueTasks(repo, window)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s %s\n", green("Notified for"), blue(fmt.Sprintf("%d task(s)", count)))
+		},
+	}
+	notifyCmd.Flags().Duration("window", 24*time.Hour, "How far ahead of the due date to start notifying")
+
+	var migrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage the database schema",
+	}
+
+	var migrateStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Print applied and pending schema migrations",
+		Run: func(cmd *cobra.Command, args []string) {
+			sqliteRepo, ok := repo.(*repository.SQLiteRepository)
+			if !ok {
+				log.Fatal("migrate status requires --store sqlite")
+			}
+			report, err := persistence.StatusReport(sqliteRepo.DB())
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, s := range report {
+				state := yellow("pending")
+				if s.Applied {
+					state = green("applied")
+				}
+				fmt.Printf("%d. %s [%s]\n", s.Version, s.Name, state)
+			}
+		},
+	}
+	migrateCmd.AddCommand(migrateStatusCmd)
+
+	var mvCmd = &cobra.Command{
+		Use:   "mv [task ID] [status]",
+		Short: "Move a task to a different status (todo, doing, done, blocked)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			status, err := ParseStatus(args[1])
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := repo.SetStatus(id, status); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s task %s to %s\n",
+				green("Moved"),
+				blue(id),
+				yellow(string(status)))
+		},
+	}
+
+	var boardCmd = &cobra.Command{
+		Use:   "board",
+		Short: "Show tasks grouped by status as a kanban board",
+		Run: func(cmd *cobra.Command, args []string) {
+			tasks, err := repo.List()
+			if err != nil {
+				log.Fatal(err)
+			}
+			printBoard(tasks)
+		},
+	}
+
+	var tuiCmd = &cobra.Command{
+		Use:   "tui",
+		Short: "Launch the interactive terminal UI",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runTUI(repo); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export all tasks to a JSON file",
+		Run: func(cmd *cobra.Command, args []string) {
+			out, _ := cmd.Flags().GetString("out")
+			if err := repository.Export(repo, out); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s %s\n", green("Exported tasks to"), blue(out))
+		},
+	}
+	exportCmd.Flags().String("out", "tasks-export.json", "Output file path")
+
+	var importCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import tasks from a JSON file produced by export",
+		Run: func(cmd *cobra.Command, args []string) {
+			in, _ := cmd.Flags().GetString("in")
+			count, err := repository.ImportFile(repo, in)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s %d task(s) from %s\n", green("Imported"), count, blue(in))
+		},
+	}
+	importCmd.Flags().String("in", "tasks-export.json", "Input file path")
+
+	rootCmd.AddCommand(addCmd, modCmd, listCmd, completeCmd, statsCmd, notifyCmd,
+		migrateCmd, mvCmd, boardCmd, tuiCmd, exportCmd, importCmd)
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -136,154 +359,113 @@ func getPriorityColor(priority int) func(a ...interface{}) string {
 	}
 }
 
-func generateStats(db *sql.DB) error {
-	// Get statistics from database
-	var totalTasks, completedTasks int
-	var priorityStats []struct {
-		Priority int
-		Count    int
+// formatDueDate renders a task's due date for `list`, color coded red when
+// overdue and yellow when due within the next 24 hours.
+func formatDueDate(task Task) string {
+	if task.DueDate == nil {
+		return ""
 	}
 
-	err := db.QueryRow(`
-        SELECT COUNT(*), SUM(CASE WHEN completed THEN 1 ELSE 0 END)
-        FROM tasks
-    `).Scan(&totalTasks, &completedTasks)
-	if err != nil {
-		return err
+	until := time.Until(*task.DueDate)
+	label := fmt.Sprintf(" (due %s)", task.DueDate.Format(dueDateLayout))
+
+	switch {
+	case task.Done():
+		return label
+	case until < 0:
+		return red(label)
+	case until < 24*time.Hour:
+		return yellow(label)
+	default:
+		return label
 	}
+}
 
-	rows, err := db.Query(`
-        SELECT priority, COUNT(*) 
-        FROM tasks 
-        GROUP BY priority 
-        ORDER BY priority
-    `)
-	if err != nil {
-		return err
+// statusMarker renders the `list` prefix for a task's status.
+func statusMarker(status Status) string {
+	switch status {
+	case StatusDone:
+		return green("[✓]")
+	case StatusDoing:
+		return yellow("[~]")
+	case StatusBlocked:
+		return magenta("[!]")
+	default:
+		return red("[ ]")
 	}
-	defer rows.Close()
+}
 
-	for rows.Next() {
-		var stat struct {
-			Priority int
-			Count    int
+// printBoard renders tasks as a kanban board, one column per status, using
+// uitable to keep columns aligned.
+func printBoard(tasks []Task) {
+	byStatus := make(map[Status][]Task)
+	rowCount := 0
+	for _, t := range tasks {
+		byStatus[t.Status] = append(byStatus[t.Status], t)
+		if n := len(byStatus[t.Status]); n > rowCount {
+			rowCount = n
 		}
-		if err := rows.Scan(&stat.Priority, &stat.Count); err != nil {
-			return err
-		}
-		priorityStats = append(priorityStats, stat)
 	}
 
-	// Create charts
-	bar := charts.NewBar()
-	bar.SetGlobalOptions(
-		charts.WithTitleOpts(opts.Title{
-			Title: "Tasks by Priority",
-		}),
-		charts.WithInitializationOpts(opts.Initialization{
-			Theme: types.ThemeWesteros,
-		}),
-	)
-
-	// Prepare data for charts
-	priorities := make([]string, 0)
-	counts := make([]opts.BarData, 0)
-	for _, stat := range priorityStats {
-		priorities = append(priorities, fmt.Sprintf("Priority %d", stat.Priority))
-		counts = append(counts, opts.BarData{Value: stat.Count})
-	}
+	table := uitable.New()
+	table.MaxColWidth = 30
+	table.Wrap = true
 
-	bar.SetXAxis(priorities).AddSeries("Tasks", counts)
+	header := make([]interface{}, len(Statuses))
+	for i, s := range Statuses {
+		header[i] = strings.ToUpper(string(s))
+	}
+	table.AddRow(header...)
+
+	for i := 0; i < rowCount; i++ {
+		row := make([]interface{}, len(Statuses))
+		for col, s := range Statuses {
+			column := byStatus[s]
+			if i < len(column) {
+				row[col] = fmt.Sprintf("#%d %s", column[i].ID, column[i].Title)
+			}
+		}
+		table.AddRow(row...)
+	}
 
-	// Create pie chart for completion status
-	pie := charts.NewPie()
-	pie.SetGlobalOptions(
-		charts.WithTitleOpts(opts.Title{
-			Title: "Task Completion Status",
-		}),
-	)
+	fmt.Println(magenta("\nBoard:"))
+	fmt.Println(table)
+}
 
-	completionData := []opts.PieData{
-		{Name: "Completed", Value: completedTasks},
-		{Name: "Pending", Value: totalTasks - completedTasks},
+// parseDueDate accepts either an absolute timestamp in dueDateLayout or a
+// relative duration ("24h", "3d", "1w") and resolves it to a point in time.
+func parseDueDate(raw string) (time.Time, error) {
+	if d, err := repository.ParseInterval(raw); err == nil {
+		return time.Now().Add(d), nil
 	}
-	pie.AddSeries("Completion", completionData)
-
-	// Save charts to HTML files
-	f1, _ := os.Create("task_priority.html")
-	bar.Render(f1)
-	f2, _ := os.Create("task_completion.html")
-	pie.Render(f2)
-
-	// Print summary statistics
-	fmt.Println(magenta("\nTask Statistics:"))
-	fmt.Println(strings.Repeat("=", 50))
-	fmt.Printf("Total Tasks: %s\n", blue(fmt.Sprintf("%d", totalTasks)))
-	fmt.Printf("Completed Tasks: %s\n", green(fmt.Sprintf("%d", completedTasks)))
-	fmt.Printf("Completion Rate: %s\n",
-		yellow(fmt.Sprintf("%.1f%%", float64(completedTasks)/float64(totalTasks)*100)))
-	fmt.Println("\nCharts have been generated:")
-	fmt.Println(green("- task_priority.html"))
-	fmt.Println(green("- task_completion.html"))
-
-	return nil
-}
 
-func initDB() (*sql.DB, error) {
-	db, err := sql.Open("sqlite", "tasks.db")
+	t, err := time.ParseInLocation(dueDateLayout, raw, time.Local)
 	if err != nil {
-		return nil, err
+		return time.Time{}, fmt.Errorf("invalid due date %q: expected %q or a duration like 24h/3d/1w", raw, dueDateLayout)
 	}
-
-	_, err = db.Exec(`
-        CREATE TABLE IF NOT EXISTS tasks (
-            id INTEGER PRIMARY KEY AUTOINCREMENT,
-            title TEXT NOT NULL,
-            priority INTEGER DEFAULT 1,
-            due_date DATETIME,
-            completed BOOLEAN DEFAULT FALSE,
-            created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-            completed_at DATETIME
-        )
-    `)
-	return db, err
+	return t, nil
 }
 
-func addTask(db *sql.DB, title string, priority int) error {
-	_, err := db.Exec(`
-        INSERT INTO tasks (title, priority) 
-        VALUES (?, ?)
-    `, title, priority)
-	return err
-}
-
-func listTasks(db *sql.DB) ([]Task, error) {
-	rows, err := db.Query(`
-        SELECT id, title, priority, completed 
-        FROM tasks 
-        ORDER BY priority DESC, created_at DESC
-    `)
+// notifyDueTasks scans incomplete tasks whose due date falls within window
+// from now (including already-overdue tasks) and fires a desktop
+// notification for each.
+func notifyDueTasks(repo repository.TaskRepository, window time.Duration) (int, error) {
+	tasks, err := repo.DueSoon(window)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
 
-	var tasks []Task
-	for rows.Next() {
-		var t Task
-		if err := rows.Scan(&t.ID, &t.Title, &t.Priority, &t.Completed); err != nil {
-			return nil, err
+	count := 0
+	for _, t := range tasks {
+		body := fmt.Sprintf("Due %s", t.DueDate.Format(dueDateLayout))
+		if t.DueDate.Before(time.Now()) {
+			body = fmt.Sprintf("Overdue since %s", t.DueDate.Format(dueDateLayout))
+		}
+		if err := beeep.Notify(fmt.Sprintf("Task #%d: %s", t.ID, t.Title), body, ""); err != nil {
+			return count, err
 		}
-		tasks = append(tasks, t)
+		count++
 	}
-	return tasks, nil
+	return count, nil
 }
 
-func completeTask(db *sql.DB, id string) error {
-	_, err := db.Exec(`
-        UPDATE tasks 
-        SET completed = TRUE, completed_at = CURRENT_TIMESTAMP 
-        WHERE id = ?
-    `, id)
-	return err
-}
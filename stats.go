@@ -0,0 +1,407 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/go-echarts/go-echarts/v2/types"
+)
+
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// sqliteTimeFormat is the text layout SQLite's own CURRENT_TIMESTAMP writes
+// ("YYYY-MM-DD HH:MM:SS"). time.Time query parameters must be formatted to
+// this layout before binding, since the sqlite driver otherwise encodes them
+// with time.Time.String(), which SQLite's datetime functions can't parse.
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+// statsReport is the raw aggregate data behind the dashboard, written
+// alongside it as stats.json for external tooling.
+type statsReport struct {
+	Since              string           `json:"since"`
+	Until              string           `json:"until"`
+	TotalTasks         int              `json:"total_tasks"`
+	CompletedTasks     int              `json:"completed_tasks"`
+	CompletionRate     float64          `json:"completion_rate"`
+	ByPriority         []priorityCount  `json:"by_priority"`
+	ByStatus           []statusCount    `json:"by_status"`
+	CompletionsPerDay  map[string]int   `json:"completions_per_day"`
+	WindowCompletedPct float64          `json:"window_completion_rate"`
+	CreatedHeatmap     []heatmapCell    `json:"created_heatmap"`
+	CompletedHeatmap   []heatmapCell    `json:"completed_heatmap"`
+}
+
+type priorityCount struct {
+	Priority int `json:"priority"`
+	Count    int `json:"count"`
+}
+
+type statusCount struct {
+	Priority int    `json:"priority"`
+	Status   string `json:"status"`
+	Count    int    `json:"count"`
+}
+
+type heatmapCell struct {
+	Weekday string `json:"weekday"`
+	Hour    int    `json:"hour"`
+	Count   int    `json:"count"`
+}
+
+// generateStats is SQLite-specific: it groups directly in SQL and renders
+// go-echarts charts, so it's only wired up behind `--store sqlite`. since
+// and until scope the completions-per-day line chart and the rolling
+// completion-rate gauge; everything else reports over all time.
+func generateStats(db *sql.DB, since, until time.Time, outPath string) error {
+	// completed_at/created_at are populated by SQLite's CURRENT_TIMESTAMP,
+	// which is always UTC. since/until arrive in whatever zone the caller
+	// built them in (e.g. time.Local from a --since/--until flag), so they
+	// must be normalized to UTC before they're used for comparison or to
+	// label calendar days, or the window is off by the zone offset.
+	since = since.UTC()
+	until = until.UTC()
+
+	var totalTasks, completedTasks int
+	if err := db.QueryRow(`
+        SELECT COUNT(*), SUM(CASE WHEN status = 'done' THEN 1 ELSE 0 END)
+        FROM tasks
+    `).Scan(&totalTasks, &completedTasks); err != nil {
+		return err
+	}
+
+	priorityStats, err := queryPriorityCounts(db)
+	if err != nil {
+		return err
+	}
+
+	statusCounts, statusByPriority, err := queryStatusCounts(db)
+	if err != nil {
+		return err
+	}
+
+	completionsPerDay, err := queryCompletionsPerDay(db, since, until)
+	if err != nil {
+		return err
+	}
+
+	windowRate, err := queryWindowCompletionRate(db, since, until)
+	if err != nil {
+		return err
+	}
+
+	createdHeatmap, err := queryActivityHeatmap(db, "created_at")
+	if err != nil {
+		return err
+	}
+	completedHeatmap, err := queryActivityHeatmap(db, "completed_at")
+	if err != nil {
+		return err
+	}
+
+	page := components.NewPage()
+	page.PageTitle = "Task Dashboard"
+	page.AddCharts(
+		priorityBarChart(priorityStats),
+		statusBarChart(priorityStats, statusByPriority),
+		completionPieChart(totalTasks, completedTasks),
+		completionsLineChart(completionsPerDay, since, until),
+		activityHeatMapChart("Created by weekday/hour", createdHeatmap),
+		activityHeatMapChart("Completed by weekday/hour", completedHeatmap),
+		completionRateGauge(windowRate),
+	)
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := page.Render(f); err != nil {
+		return err
+	}
+
+	report := statsReport{
+		Since:              since.Format("2006-01-02"),
+		Until:              until.Format("2006-01-02"),
+		TotalTasks:         totalTasks,
+		CompletedTasks:     completedTasks,
+		CompletionRate:     rate(completedTasks, totalTasks),
+		ByPriority:         priorityStats,
+		ByStatus:           statusCounts,
+		CompletionsPerDay:  completionsPerDay,
+		WindowCompletedPct: windowRate,
+		CreatedHeatmap:     createdHeatmap,
+		CompletedHeatmap:   completedHeatmap,
+	}
+	statsJSONPath := filepath.Join(filepath.Dir(outPath), "stats.json")
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(statsJSONPath, data, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println(magenta("\nTask Statistics:"))
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Total Tasks: %s\n", blue(fmt.Sprintf("%d", totalTasks)))
+	fmt.Printf("Completed Tasks: %s\n", green(fmt.Sprintf("%d", completedTasks)))
+	fmt.Printf("Completion Rate: %s\n", yellow(fmt.Sprintf("%.1f%%", report.CompletionRate)))
+	fmt.Printf("Window Completion Rate (%s to %s): %s\n",
+		report.Since, report.Until, yellow(fmt.Sprintf("%.1f%%", windowRate)))
+	fmt.Println("\nReport written:")
+	fmt.Println(green("- " + outPath))
+	fmt.Println(green("- " + statsJSONPath))
+
+	return nil
+}
+
+func rate(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+func queryPriorityCounts(db *sql.DB) ([]priorityCount, error) {
+	rows, err := db.Query(`
+        SELECT priority, COUNT(*)
+        FROM tasks
+        GROUP BY priority
+        ORDER BY priority
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []priorityCount
+	for rows.Next() {
+		var c priorityCount
+		if err := rows.Scan(&c.Priority, &c.Count); err != nil {
+			return nil, err
+		}
+		stats = append(stats, c)
+	}
+	return stats, rows.Err()
+}
+
+func queryStatusCounts(db *sql.DB) ([]statusCount, map[Status]map[int]int, error) {
+	rows, err := db.Query(`
+        SELECT priority, status, COUNT(*)
+        FROM tasks
+        GROUP BY priority, status
+        ORDER BY priority
+    `)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	byPriority := make(map[Status]map[int]int)
+	for _, s := range Statuses {
+		byPriority[s] = make(map[int]int)
+	}
+
+	var flat []statusCount
+	for rows.Next() {
+		var priority, count int
+		var status string
+		if err := rows.Scan(&priority, &status, &count); err != nil {
+			return nil, nil, err
+		}
+		byPriority[Status(status)][priority] = count
+		flat = append(flat, statusCount{Priority: priority, Status: status, Count: count})
+	}
+	return flat, byPriority, rows.Err()
+}
+
+// queryCompletionsPerDay returns a count of completions per calendar day in
+// [since, until], with every day in range present (zero-filled).
+func queryCompletionsPerDay(db *sql.DB, since, until time.Time) (map[string]int, error) {
+	// completed_at is populated by SQLite's CURRENT_TIMESTAMP, which stores
+	// "YYYY-MM-DD HH:MM:SS" text. The sqlite driver binds a Go time.Time
+	// parameter using its own String() layout, so the two sides never match
+	// as written. Format since/until to SQLite's native text layout in Go
+	// before binding so the comparison is a plain string comparison.
+	rows, err := db.Query(`
+        SELECT date(completed_at), COUNT(*)
+        FROM tasks
+        WHERE completed_at IS NOT NULL AND completed_at >= ? AND completed_at <= ?
+        GROUP BY date(completed_at)
+    `, since.Format(sqliteTimeFormat), until.Format(sqliteTimeFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for d := since; !d.After(until); d = d.AddDate(0, 0, 1) {
+		counts[d.Format("2006-01-02")] = 0
+	}
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		counts[day] = count
+	}
+	return counts, rows.Err()
+}
+
+func queryWindowCompletionRate(db *sql.DB, since, until time.Time) (float64, error) {
+	var total int
+	var completed sql.NullInt64
+	err := db.QueryRow(`
+        SELECT COUNT(*), SUM(CASE WHEN status = 'done' THEN 1 ELSE 0 END)
+        FROM tasks
+        WHERE created_at >= ? AND created_at <= ?
+    `, since.Format(sqliteTimeFormat), until.Format(sqliteTimeFormat)).Scan(&total, &completed)
+	if err != nil {
+		return 0, err
+	}
+	return rate(int(completed.Int64), total), nil
+}
+
+// queryActivityHeatmap buckets rows by weekday and hour of the given
+// timestamp column ("created_at" or "completed_at").
+func queryActivityHeatmap(db *sql.DB, column string) ([]heatmapCell, error) {
+	query := fmt.Sprintf(`
+        SELECT CAST(strftime('%%w', %s) AS INTEGER), CAST(strftime('%%H', %s) AS INTEGER), COUNT(*)
+        FROM tasks
+        WHERE %s IS NOT NULL
+        GROUP BY 1, 2
+    `, column, column, column)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cells []heatmapCell
+	for rows.Next() {
+		var weekday, hour, count int
+		if err := rows.Scan(&weekday, &hour, &count); err != nil {
+			return nil, err
+		}
+		cells = append(cells, heatmapCell{Weekday: weekdays[weekday], Hour: hour, Count: count})
+	}
+	return cells, rows.Err()
+}
+
+func priorityBarChart(priorityStats []priorityCount) *charts.Bar {
+	bar := charts.NewBar()
+	bar.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Tasks by Priority"}),
+		charts.WithInitializationOpts(opts.Initialization{Theme: types.ThemeWesteros}),
+	)
+
+	priorities := make([]string, len(priorityStats))
+	counts := make([]opts.BarData, len(priorityStats))
+	for i, stat := range priorityStats {
+		priorities[i] = fmt.Sprintf("Priority %d", stat.Priority)
+		counts[i] = opts.BarData{Value: stat.Count}
+	}
+	bar.SetXAxis(priorities).AddSeries("Tasks", counts)
+	return bar
+}
+
+func statusBarChart(priorityStats []priorityCount, byPriority map[Status]map[int]int) *charts.Bar {
+	bar := charts.NewBar()
+	bar.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Tasks by Status"}),
+		charts.WithInitializationOpts(opts.Initialization{Theme: types.ThemeWesteros}),
+	)
+
+	priorities := make([]string, len(priorityStats))
+	for i, stat := range priorityStats {
+		priorities[i] = fmt.Sprintf("Priority %d", stat.Priority)
+	}
+	bar.SetXAxis(priorities)
+
+	for _, s := range Statuses {
+		data := make([]opts.BarData, len(priorityStats))
+		for i, stat := range priorityStats {
+			data[i] = opts.BarData{Value: byPriority[s][stat.Priority]}
+		}
+		bar.AddSeries(string(s), data, charts.WithBarChartOpts(opts.BarChart{Stack: "status"}))
+	}
+	return bar
+}
+
+func completionPieChart(total, completed int) *charts.Pie {
+	pie := charts.NewPie()
+	pie.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Task Completion Status"}),
+	)
+	pie.AddSeries("Completion", []opts.PieData{
+		{Name: "Completed", Value: completed},
+		{Name: "Pending", Value: total - completed},
+	})
+	return pie
+}
+
+func completionsLineChart(completionsPerDay map[string]int, since, until time.Time) *charts.Line {
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Completions per Day"}),
+		charts.WithInitializationOpts(opts.Initialization{Theme: types.ThemeWesteros}),
+	)
+
+	var days []string
+	var data []opts.LineData
+	for d := since; !d.After(until); d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		days = append(days, day)
+		data = append(data, opts.LineData{Value: completionsPerDay[day]})
+	}
+	line.SetXAxis(days).AddSeries("Completed", data)
+	return line
+}
+
+func activityHeatMapChart(title string, cells []heatmapCell) *charts.HeatMap {
+	hours := make([]string, 24)
+	for h := 0; h < 24; h++ {
+		hours[h] = fmt.Sprintf("%02d", h)
+	}
+
+	heatmap := charts.NewHeatMap()
+	heatmap.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: title}),
+		charts.WithXAxisOpts(opts.XAxis{Type: "category", Data: hours}),
+		charts.WithYAxisOpts(opts.YAxis{Type: "category", Data: weekdays}),
+		charts.WithVisualMapOpts(opts.VisualMap{Calculable: opts.Bool(true)}),
+	)
+
+	data := make([]opts.HeatMapData, len(cells))
+	for i, cell := range cells {
+		weekdayIndex := 0
+		for idx, w := range weekdays {
+			if w == cell.Weekday {
+				weekdayIndex = idx
+				break
+			}
+		}
+		data[i] = opts.HeatMapData{Value: [3]interface{}{cell.Hour, weekdayIndex, cell.Count}}
+	}
+	heatmap.AddSeries("Activity", data)
+	return heatmap
+}
+
+func completionRateGauge(rate float64) *charts.Gauge {
+	gauge := charts.NewGauge()
+	gauge.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Rolling Completion Rate"}),
+	)
+	gauge.AddSeries("Completion Rate", []opts.GaugeData{{Name: "rate", Value: rate}})
+	return gauge
+}
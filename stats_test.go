@@ -0,0 +1,140 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Phillip-D-Shields/tracker-thingie/internal/persistence"
+	_ "modernc.org/sqlite"
+)
+
+func openTestStatsDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := persistence.Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return db
+}
+
+// TestQueryCompletionsPerDay_MatchesCURRENT_TIMESTAMPFormat guards against
+// since/until (bound as time.Time) failing to compare against completed_at
+// values written by SQLite's own CURRENT_TIMESTAMP, which stores a different
+// text layout than the driver encodes Go's time.Time as.
+func TestQueryCompletionsPerDay_MatchesCURRENT_TIMESTAMPFormat(t *testing.T) {
+	db := openTestStatsDB(t)
+
+	if _, err := db.Exec(`
+        INSERT INTO tasks (title, priority, status, completed, completed_at)
+        VALUES ('done today', 1, 'done', TRUE, CURRENT_TIMESTAMP)
+    `); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -1)
+
+	counts, err := queryCompletionsPerDay(db, since, until)
+	if err != nil {
+		t.Fatalf("queryCompletionsPerDay: %v", err)
+	}
+
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total != 1 {
+		t.Errorf("counts = %v, want exactly one completion somewhere in range", counts)
+	}
+}
+
+func TestQueryWindowCompletionRate_MatchesCURRENT_TIMESTAMPFormat(t *testing.T) {
+	db := openTestStatsDB(t)
+
+	if _, err := db.Exec(`
+        INSERT INTO tasks (title, priority, status, completed)
+        VALUES ('created today', 1, 'done', TRUE)
+    `); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -1)
+
+	rate, err := queryWindowCompletionRate(db, since, until)
+	if err != nil {
+		t.Fatalf("queryWindowCompletionRate: %v", err)
+	}
+	if rate != 100 {
+		t.Errorf("rate = %v, want 100", rate)
+	}
+}
+
+// TestQueryWindowCompletionRate_NoTasksInRange guards against SUM(CASE ...)
+// returning SQL NULL when zero rows fall in [since, until], which previously
+// failed the int Scan with "converting NULL to int is unsupported".
+func TestQueryWindowCompletionRate_NoTasksInRange(t *testing.T) {
+	db := openTestStatsDB(t)
+
+	until := time.Now().UTC().AddDate(0, 0, -30)
+	since := until.AddDate(0, 0, -1)
+
+	rate, err := queryWindowCompletionRate(db, since, until)
+	if err != nil {
+		t.Fatalf("queryWindowCompletionRate: %v", err)
+	}
+	if rate != 0 {
+		t.Errorf("rate = %v, want 0 for an empty window", rate)
+	}
+}
+
+// TestGenerateStats_NormalizesSinceUntilToUTC guards against since/until
+// built in a non-UTC location failing to match completed_at/created_at,
+// which SQLite always populates via CURRENT_TIMESTAMP in UTC.
+func TestGenerateStats_NormalizesSinceUntilToUTC(t *testing.T) {
+	db := openTestStatsDB(t)
+
+	if _, err := db.Exec(`
+        INSERT INTO tasks (title, priority, status, completed, completed_at)
+        VALUES ('done today', 1, 'done', TRUE, CURRENT_TIMESTAMP)
+    `); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	farEast := time.FixedZone("UTC+14", 14*60*60)
+	until := time.Now().In(farEast)
+	since := until.AddDate(0, 0, -1)
+
+	outPath := filepath.Join(t.TempDir(), "dashboard.html")
+	if err := generateStats(db, since, until, outPath); err != nil {
+		t.Fatalf("generateStats: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(outPath), "stats.json"))
+	if err != nil {
+		t.Fatalf("reading stats.json: %v", err)
+	}
+	var report statsReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("unmarshal stats.json: %v", err)
+	}
+
+	if report.WindowCompletedPct != 100 {
+		t.Errorf("WindowCompletedPct = %v, want 100 (since/until should be normalized to UTC before comparing)", report.WindowCompletedPct)
+	}
+	total := 0
+	for _, c := range report.CompletionsPerDay {
+		total += c
+	}
+	if total != 1 {
+		t.Errorf("CompletionsPerDay totals = %v, want exactly one completion somewhere in range", report.CompletionsPerDay)
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Phillip-D-Shields/tracker-thingie/internal/repository"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const tuiConfigDirName = "tasks-cli"
+
+// tuiConfig is persisted to os.UserConfigDir so the TUI reopens at the same
+// size and filter the user left it at.
+type tuiConfig struct {
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	LastFilter string `json:"last_filter"`
+}
+
+func tuiConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, tuiConfigDirName, "tui.json"), nil
+}
+
+func loadTUIConfig() tuiConfig {
+	path, err := tuiConfigPath()
+	if err != nil {
+		return tuiConfig{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tuiConfig{}
+	}
+	var cfg tuiConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return tuiConfig{}
+	}
+	return cfg
+}
+
+func saveTUIConfig(cfg tuiConfig) error {
+	path, err := tuiConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// taskItem adapts Task to bubbles/list.Item so it can be filtered and
+// rendered by the list component.
+type taskItem struct {
+	task Task
+}
+
+func (i taskItem) Title() string {
+	return fmt.Sprintf("%s #%d %s", statusMarker(i.task.Status), i.task.ID, i.task.Title)
+}
+
+func (i taskItem) Description() string {
+	due := "no due date"
+	if i.task.DueDate != nil {
+		due = "due " + i.task.DueDate.Format(dueDateLayout)
+	}
+	desc := fmt.Sprintf("priority %d · %s", i.task.Priority, due)
+	if len(i.task.Tags) > 0 {
+		desc += " · #" + strings.Join(i.task.Tags, " #")
+	}
+	return desc
+}
+
+// FilterValue feeds bubbles/list's built-in filtering, so the filter input
+// narrows by title or tag substring.
+func (i taskItem) FilterValue() string {
+	return i.task.Title + " " + strings.Join(i.task.Tags, " ")
+}
+
+type editMode int
+
+const (
+	editNone editMode = iota
+	editAdding
+	editRenaming
+	editTagging
+)
+
+type tuiModel struct {
+	repo   repository.TaskRepository
+	list   list.Model
+	input  textinput.Model
+	mode   editMode
+	config tuiConfig
+	err    error
+}
+
+func newTUIModel(repo repository.TaskRepository, cfg tuiConfig) (tuiModel, error) {
+	tasks, err := repo.List()
+	if err != nil {
+		return tuiModel{}, err
+	}
+
+	l := list.New(taskItems(tasks), list.NewDefaultDelegate(), cfg.Width, cfg.Height)
+	l.Title = "Tasks"
+	l.SetFilteringEnabled(true)
+	if cfg.LastFilter != "" {
+		l = applyFilter(l, cfg.LastFilter)
+	}
+
+	input := textinput.New()
+	input.Placeholder = "Task title"
+
+	return tuiModel{repo: repo, list: l, input: input, config: cfg}, nil
+}
+
+// applyFilter restores a filter that was previously typed and accepted by
+// the user, by replaying the same keystrokes bubbles/list itself expects:
+// setting FilterInput's value directly leaves the list's internal
+// filteredItems unset, so VisibleItems() would keep returning every item.
+// Driving it through Update is the only way to reach that unexported state.
+func applyFilter(l list.Model, query string) list.Model {
+	l, cmd := l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	l = runFilterCmd(l, cmd)
+	l, cmd = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+	l = runFilterCmd(l, cmd)
+	l, cmd = l.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	l = runFilterCmd(l, cmd)
+	return l
+}
+
+// runFilterCmd resolves cmd (and any commands it batches) synchronously,
+// feeding list.FilterMatchesMsg back into l.Update so filteredItems gets
+// populated. One of the batched commands is the filter textinput's cursor
+// blink timer, which blocks for one blink interval before resolving to a
+// message we don't need; that's a one-time, bounded cost paid once at TUI
+// startup when restoring a saved filter, not a hot path.
+func runFilterCmd(l list.Model, cmd tea.Cmd) list.Model {
+	if cmd == nil {
+		return l
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			l = runFilterCmd(l, c)
+		}
+	case list.FilterMatchesMsg:
+		l, _ = l.Update(msg)
+	}
+	return l
+}
+
+// taskItems sorts tasks into the TUI's own manually-curated order (distinct
+// from List's priority-based order) before wrapping them for display.
+func taskItems(tasks []Task) []list.Item {
+	ordered := make([]Task, len(tasks))
+	copy(ordered, tasks)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+
+	items := make([]list.Item, len(ordered))
+	for i, t := range ordered {
+		items[i] = taskItem{task: t}
+	}
+	return items
+}
+
+// runTUI launches the bubbletea program. It shares the same TaskRepository
+// as the cobra commands, so edits made in the TUI are visible to `list`,
+// `board`, etc. and vice versa.
+func runTUI(repo repository.TaskRepository) error {
+	cfg := loadTUIConfig()
+	if cfg.Width == 0 {
+		cfg.Width = 80
+	}
+	if cfg.Height == 0 {
+		cfg.Height = 20
+	}
+
+	model, err := newTUIModel(repo, cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = tea.NewProgram(model, tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.config.Width = msg.Width
+		m.config.Height = msg.Height
+		m.list.SetSize(msg.Width, msg.Height-6)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.mode != editNone {
+			return m.updateEdit(msg)
+		}
+		return m.updateNormal(msg)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m tuiModel) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		m.config.LastFilter = m.list.FilterValue()
+		saveTUIConfig(m.config)
+		return m, tea.Quit
+
+	case "a":
+		m.mode = editAdding
+		m.input.SetValue("")
+		m.input.Focus()
+		return m, nil
+
+	case "e":
+		if item, ok := m.selected(); ok {
+			m.mode = editRenaming
+			m.input.SetValue(item.task.Title)
+			m.input.Focus()
+		}
+		return m, nil
+
+	case "d":
+		if item, ok := m.selected(); ok {
+			if err := m.repo.Delete(strconv.FormatInt(item.task.ID, 10)); err != nil {
+				m.err = err
+			}
+			m = m.refresh()
+		}
+		return m, nil
+
+	case "c":
+		if item, ok := m.selected(); ok {
+			if err := m.repo.Complete(strconv.FormatInt(item.task.ID, 10)); err != nil {
+				m.err = err
+			}
+			m = m.refresh()
+		}
+		return m, nil
+
+	case "+":
+		if item, ok := m.selected(); ok && item.task.Priority < 5 {
+			if err := m.repo.SetPriority(strconv.FormatInt(item.task.ID, 10), item.task.Priority+1); err != nil {
+				m.err = err
+			}
+			m = m.refresh()
+		}
+		return m, nil
+
+	case "-":
+		if item, ok := m.selected(); ok && item.task.Priority > 1 {
+			if err := m.repo.SetPriority(strconv.FormatInt(item.task.ID, 10), item.task.Priority-1); err != nil {
+				m.err = err
+			}
+			m = m.refresh()
+		}
+		return m, nil
+
+	case "K":
+		if item, ok := m.selected(); ok {
+			if err := m.repo.Reorder(strconv.FormatInt(item.task.ID, 10), -1); err != nil {
+				m.err = err
+			}
+			m = m.refresh()
+		}
+		return m, nil
+
+	case "J":
+		if item, ok := m.selected(); ok {
+			if err := m.repo.Reorder(strconv.FormatInt(item.task.ID, 10), 1); err != nil {
+				m.err = err
+			}
+			m = m.refresh()
+		}
+		return m, nil
+
+	case "t":
+		if item, ok := m.selected(); ok {
+			m.mode = editTagging
+			m.input.SetValue(strings.Join(item.task.Tags, ", "))
+			m.input.Focus()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m tuiModel) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = editNone
+		m.input.Blur()
+		return m, nil
+
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		switch m.mode {
+		case editRenaming:
+			if value != "" {
+				if item, ok := m.selected(); ok {
+					if err := m.repo.SetTitle(strconv.FormatInt(item.task.ID, 10), value); err != nil {
+						m.err = err
+					}
+				}
+			}
+		case editAdding:
+			if value != "" {
+				if _, err := m.repo.Add(value, 1, nil, nil); err != nil {
+					m.err = err
+				}
+			}
+		case editTagging:
+			if item, ok := m.selected(); ok {
+				if err := m.repo.SetTags(strconv.FormatInt(item.task.ID, 10), parseTags(value)); err != nil {
+					m.err = err
+				}
+			}
+		}
+		m.mode = editNone
+		m.input.Blur()
+		m = m.refresh()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// parseTags splits a comma-separated tag input field into trimmed, non-empty
+// tag names.
+func parseTags(raw string) []string {
+	var tags []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			tags = append(tags, part)
+		}
+	}
+	return tags
+}
+
+func (m tuiModel) refresh() tuiModel {
+	tasks, err := m.repo.List()
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.list.SetItems(taskItems(tasks))
+	return m
+}
+
+func (m tuiModel) selected() (taskItem, bool) {
+	item, ok := m.list.SelectedItem().(taskItem)
+	return item, ok
+}
+
+var detailStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	Padding(0, 1).
+	Width(32)
+
+func (m tuiModel) View() string {
+	if m.mode != editNone {
+		return fmt.Sprintf("%s\n\n%s\n\n(enter to save, esc to cancel)", m.list.View(), m.input.View())
+	}
+
+	detail := "select a task to see details"
+	if item, ok := m.selected(); ok {
+		detail = renderDetail(item.task)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), detailStyle.Render(detail))
+}
+
+func renderDetail(t Task) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Task #%d\n", t.ID)
+	fmt.Fprintf(&b, "Status: %s\n", t.Status)
+	fmt.Fprintf(&b, "Priority: %d\n", t.Priority)
+	if len(t.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: #%s\n", strings.Join(t.Tags, " #"))
+	}
+	if t.DueDate != nil {
+		fmt.Fprintf(&b, "Due: %s\n", t.DueDate.Format(dueDateLayout))
+	}
+	fmt.Fprintf(&b, "Created: %s\n", t.CreatedAt.Format(dueDateLayout))
+	if t.CompletedAt != nil {
+		fmt.Fprintf(&b, "Completed: %s\n", t.CompletedAt.Format(dueDateLayout))
+	}
+	if t.Notes != nil && *t.Notes != "" {
+		fmt.Fprintf(&b, "\nNotes:\n%s\n", *t.Notes)
+	}
+	return b.String()
+}
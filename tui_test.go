@@ -0,0 +1,39 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Phillip-D-Shields/tracker-thingie/internal/repository"
+)
+
+// TestNewTUIModel_RestoresLastFilter guards against LastFilter only
+// populating the filter textbox without narrowing the list: bubbles/list
+// only returns the filtered subset from VisibleItems() once filteredItems
+// has been computed via its own Update loop, which a plain
+// FilterInput.SetValue never triggers.
+func TestNewTUIModel_RestoresLastFilter(t *testing.T) {
+	repo, err := repository.OpenJSONFile(filepath.Join(t.TempDir(), "tasks.json"))
+	if err != nil {
+		t.Fatalf("OpenJSONFile: %v", err)
+	}
+
+	if _, err := repo.Add("apple pie", 1, nil, nil); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := repo.Add("banana bread", 1, nil, nil); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	m, err := newTUIModel(repo, tuiConfig{Width: 80, Height: 24, LastFilter: "apple"})
+	if err != nil {
+		t.Fatalf("newTUIModel: %v", err)
+	}
+
+	if got := m.list.FilterInput.Value(); got != "apple" {
+		t.Fatalf("FilterInput.Value() = %q, want %q", got, "apple")
+	}
+	if visible := m.list.VisibleItems(); len(visible) != 1 {
+		t.Errorf("VisibleItems() = %d items, want 1 (restored filter should narrow the list)", len(visible))
+	}
+}